@@ -0,0 +1,99 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package main
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/mholt/archiver/v3"
+	"golang.org/x/sync/semaphore"
+	"golang.org/x/sync/singleflight"
+)
+
+// maxParallelExtractions bounds how many archives can be unpacked at once,
+// regardless of how many distinct runtimes are being requested concurrently.
+// Extraction is CPU/disk bound, so letting it grow unbounded under load just
+// thrashes rather than finishing any faster.
+const maxParallelExtractions = 4
+
+// extractGroup collapses concurrent requests for the same cache entry into
+// a single download+extract; a second caller asking for the runtime that's
+// already being fetched waits for and shares that result instead of
+// racing it, while requests for different runtimes proceed in parallel.
+var extractGroup singleflight.Group
+
+var extractSemaphore = semaphore.NewWeighted(maxParallelExtractions)
+
+// downloadAndExtract downloads release.Link with client and unpacks it into
+// RT_CACHE, returning the path to the extracted JDK home. Shared by every
+// provider since the archive format and cache layout are vendor-agnostic.
+func downloadAndExtract(client *http.Client, release *releaseBinary) (string, error) {
+	output := cacheOutputPath(release)
+
+	if _, e := os.Stat(output); !os.IsNotExist(e) {
+		return output + "/jdk-" + release.ReleaseVersion.Version, nil
+	}
+
+	v, err, _ := extractGroup.Do(output, func() (interface{}, error) {
+		return fetchAndExtract(client, release, output)
+	})
+	if err != nil {
+		return "", err
+	}
+	return v.(string), nil
+}
+
+// fetchAndExtract downloads and unpacks release into output, bounded by
+// extractSemaphore. It extracts into a scratch directory beside output and
+// promotes it with a single os.Rename only once fully populated, so a crash
+// mid-extract never leaves a half-populated cache entry that the os.Stat
+// check in downloadAndExtract would mistake for a valid one.
+func fetchAndExtract(client *http.Client, release *releaseBinary, output string) (string, error) {
+	if err := extractSemaphore.Acquire(context.Background(), 1); err != nil {
+		return "", err
+	}
+	defer extractSemaphore.Release(1)
+
+	// Re-check now that we hold a slot: another goroutine may have
+	// populated the cache while we were waiting on the semaphore.
+	if _, e := os.Stat(output); !os.IsNotExist(e) {
+		return output + "/jdk-" + release.ReleaseVersion.Version, nil
+	}
+
+	archive, dir := newTemporaryFile(release.FileName)
+	defer os.RemoveAll(dir)
+
+	if err := verifyingDownload(client, release, archive); err != nil {
+		return "", err
+	}
+
+	scratch := output + ".tmp"
+	defer os.RemoveAll(scratch)
+
+	if err := archiver.Unarchive(archive, scratch); err != nil {
+		return "", err
+	}
+	if err := os.Rename(scratch, output); err != nil {
+		return "", err
+	}
+
+	return output + "/jdk-" + release.ReleaseVersion.Version, nil
+}
+
+// cacheOutputPath is the RT_CACHE directory a release's archive extracts
+// into.
+func cacheOutputPath(release *releaseBinary) string {
+	return RT_CACHE + "/" + strings.TrimSuffix(strings.TrimSuffix(release.FileName, ".zip"), ".tar.gz")
+}
@@ -0,0 +1,105 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package main
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+// buildTestArchive returns the bytes of a minimal valid tar.gz containing a
+// single small file, suitable for archiver.Unarchive to extract.
+func buildTestArchive(t *testing.T) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	gw := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gw)
+
+	contents := []byte("hello")
+	if err := tw.WriteHeader(&tar.Header{Name: "jdk-1.0/release", Size: int64(len(contents)), Mode: 0644}); err != nil {
+		t.Fatalf("writing tar header: %v", err)
+	}
+	if _, err := tw.Write(contents); err != nil {
+		t.Fatalf("writing tar contents: %v", err)
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatalf("closing tar writer: %v", err)
+	}
+	if err := gw.Close(); err != nil {
+		t.Fatalf("closing gzip writer: %v", err)
+	}
+	return buf.Bytes()
+}
+
+// TestDownloadAndExtractCollapsesConcurrentCalls proves the core claim of
+// the singleflight-backed cache: many concurrent requests for the same
+// release only ever trigger one download+extract, with every caller
+// getting back the same result.
+func TestDownloadAndExtractCollapsesConcurrentCalls(t *testing.T) {
+	archive := buildTestArchive(t)
+	var requests int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		w.Header().Set("Content-Length", fmt.Sprintf("%d", len(archive)))
+		w.Write(archive)
+	}))
+	defer server.Close()
+
+	release := &releaseBinary{
+		FileName:       "cache-dedup-test.tar.gz",
+		Link:           server.URL,
+		Size:           int64(len(archive)),
+		ReleaseVersion: releaseVersion{Version: "1.0"},
+	}
+
+	output := cacheOutputPath(release)
+	os.RemoveAll(output)
+	t.Cleanup(func() { os.RemoveAll(output) })
+
+	const concurrency = 8
+	var wg sync.WaitGroup
+	results := make([]string, concurrency)
+	errs := make([]error, concurrency)
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i], errs[i] = downloadAndExtract(server.Client(), release)
+		}(i)
+	}
+	wg.Wait()
+
+	for i, err := range errs {
+		if err != nil {
+			t.Fatalf("downloadAndExtract[%d]: %v", i, err)
+		}
+	}
+	for i := 1; i < concurrency; i++ {
+		if results[i] != results[0] {
+			t.Errorf("downloadAndExtract[%d] = %q, want %q (same as [0])", i, results[i], results[0])
+		}
+	}
+
+	if got := atomic.LoadInt32(&requests); got != 1 {
+		t.Errorf("server saw %d requests, want 1 (singleflight should collapse concurrent fetches for the same release)", got)
+	}
+}
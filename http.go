@@ -0,0 +1,158 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package main
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"flag"
+	"fmt"
+	"hash"
+	"io"
+	"net/http"
+	"os"
+	"time"
+)
+
+// maxArchiveSize rejects any archive larger than this, so a rogue or
+// compromised mirror can't fill the disk. The largest legitimate images
+// this service downloads (full OpenJ9 JDKs) are a few hundred MB.
+var maxArchiveSize = flag.Int64("max-archive-size", 1<<30, "reject archive downloads larger than this many bytes")
+
+// downloadTimeout bounds a single archive download end-to-end, including
+// every retry.
+var downloadTimeout = flag.Duration("download-timeout", 15*time.Minute, "deadline for a single archive download, including retries")
+
+const maxDownloadAttempts = 5
+
+// retryableError marks an error that's worth retrying (a 5xx response or a
+// transport-level hiccup) as opposed to one that never will succeed, like
+// the archive simply being too large.
+type retryableError struct{ error }
+
+func (e retryableError) Unwrap() error { return e.error }
+
+// download streams release.Link into dest, resuming with a Range request
+// if a previous attempt left a partial file, retrying with exponential
+// backoff on 5xx responses and transport errors, and returns the
+// hex-encoded SHA256 of the bytes written. Computing the digest off the
+// same io.TeeReader used for the copy means callers that need to verify a
+// checksum don't have to read the file back off disk afterward.
+func download(client *http.Client, release *releaseBinary, dest string) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), *downloadTimeout)
+	defer cancel()
+
+	hasher := sha256.New()
+	backoff := time.Second
+
+	var lastErr error
+	for attempt := 0; attempt < maxDownloadAttempts; attempt++ {
+		if attempt > 0 {
+			select {
+			case <-time.After(backoff):
+			case <-ctx.Done():
+				return "", ctx.Err()
+			}
+			backoff *= 2
+		}
+
+		err := attemptDownload(ctx, client, release, dest, hasher)
+		if err == nil {
+			return hex.EncodeToString(hasher.Sum(nil)), nil
+		}
+
+		var retryable retryableError
+		if !errors.As(err, &retryable) {
+			return "", err
+		}
+		lastErr = err
+	}
+
+	return "", fmt.Errorf("download of %s failed after %d attempts: %w", release.FileName, maxDownloadAttempts, lastErr)
+}
+
+// attemptDownload makes one attempt at filling dest with release.Link,
+// resuming from dest's current size via a Range request when it's
+// non-empty. hasher accumulates only the bytes newly written on this
+// attempt, so it stays correct across resumes within the same download
+// call.
+func attemptDownload(ctx context.Context, client *http.Client, release *releaseBinary, dest string, hasher hash.Hash) error {
+	var existing int64
+	if fi, err := os.Stat(dest); err == nil {
+		existing = fi.Size()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, release.Link, nil)
+	if err != nil {
+		return err
+	}
+	if existing > 0 {
+		req.Header.Set("Range", fmt.Sprintf("bytes=%d-", existing))
+	}
+
+	res, err := client.Do(req)
+	if err != nil {
+		return retryableError{err}
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode >= 500 {
+		return retryableError{fmt.Errorf("server error downloading %s: %s", release.FileName, res.Status)}
+	}
+
+	resuming := existing > 0 && res.StatusCode == http.StatusPartialContent
+	if existing > 0 && !resuming {
+		// The server ignored our Range request; start over from scratch.
+		existing = 0
+		hasher.Reset()
+		if err := os.Remove(dest); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	if res.StatusCode != http.StatusOK && res.StatusCode != http.StatusPartialContent {
+		return fmt.Errorf("unexpected status downloading %s: %s", release.FileName, res.Status)
+	}
+
+	if release.Size > 0 && res.ContentLength >= 0 && existing+res.ContentLength != release.Size {
+		return fmt.Errorf("content-length %d doesn't match expected size %d for %s",
+			existing+res.ContentLength, release.Size, release.FileName)
+	}
+
+	flags := os.O_CREATE | os.O_WRONLY
+	if resuming {
+		flags |= os.O_APPEND
+	} else {
+		flags |= os.O_TRUNC
+	}
+	f, err := os.OpenFile(dest, flags, 0644)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	// Cap reads at one byte past the limit so we can tell "hit the limit
+	// exactly" apart from "went over it" without buffering the whole body.
+	limit := *maxArchiveSize - existing + 1
+	written, err := io.Copy(f, io.TeeReader(io.LimitReader(res.Body, limit), hasher))
+	if err != nil {
+		return retryableError{err}
+	}
+
+	if existing+written > *maxArchiveSize {
+		os.Remove(dest)
+		return fmt.Errorf("archive for %s exceeds max size of %d bytes", release.FileName, *maxArchiveSize)
+	}
+
+	return nil
+}
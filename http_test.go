@@ -0,0 +1,165 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package main
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestAttemptDownloadFull(t *testing.T) {
+	body := "the quick brown fox jumps over the lazy dog"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Length", fmt.Sprintf("%d", len(body)))
+		w.Write([]byte(body))
+	}))
+	defer server.Close()
+
+	dest := filepath.Join(t.TempDir(), "archive")
+	release := &releaseBinary{FileName: "test.tar.gz", Link: server.URL, Size: int64(len(body))}
+
+	got, err := download(server.Client(), release, dest)
+	if err != nil {
+		t.Fatalf("download: %v", err)
+	}
+
+	want := sha256.Sum256([]byte(body))
+	if got != hex.EncodeToString(want[:]) {
+		t.Errorf("download hash = %s, want %s", got, hex.EncodeToString(want[:]))
+	}
+
+	contents, err := os.ReadFile(dest)
+	if err != nil {
+		t.Fatalf("reading dest: %v", err)
+	}
+	if string(contents) != body {
+		t.Errorf("dest contents = %q, want %q", contents, body)
+	}
+}
+
+func TestAttemptDownloadResumesWithRange(t *testing.T) {
+	body := "the quick brown fox jumps over the lazy dog"
+	existing := body[:10]
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rangeHeader := r.Header.Get("Range")
+		if rangeHeader != "bytes=10-" {
+			t.Errorf("Range header = %q, want %q", rangeHeader, "bytes=10-")
+		}
+		w.Header().Set("Content-Length", fmt.Sprintf("%d", len(body)-10))
+		w.WriteHeader(http.StatusPartialContent)
+		w.Write([]byte(body[10:]))
+	}))
+	defer server.Close()
+
+	dest := filepath.Join(t.TempDir(), "archive")
+	if err := os.WriteFile(dest, []byte(existing), 0644); err != nil {
+		t.Fatalf("seeding partial file: %v", err)
+	}
+
+	release := &releaseBinary{FileName: "test.tar.gz", Link: server.URL, Size: int64(len(body))}
+	if err := attemptDownload(context.Background(), server.Client(), release, dest, sha256.New()); err != nil {
+		t.Fatalf("attemptDownload: %v", err)
+	}
+
+	contents, err := os.ReadFile(dest)
+	if err != nil {
+		t.Fatalf("reading dest: %v", err)
+	}
+	if string(contents) != body {
+		t.Errorf("dest contents = %q, want %q", contents, body)
+	}
+}
+
+func TestAttemptDownloadRestartsWhenServerIgnoresRange(t *testing.T) {
+	body := "the quick brown fox jumps over the lazy dog"
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Length", fmt.Sprintf("%d", len(body)))
+		w.Write([]byte(body))
+	}))
+	defer server.Close()
+
+	dest := filepath.Join(t.TempDir(), "archive")
+	if err := os.WriteFile(dest, []byte("stale partial content"), 0644); err != nil {
+		t.Fatalf("seeding partial file: %v", err)
+	}
+
+	release := &releaseBinary{FileName: "test.tar.gz", Link: server.URL, Size: int64(len(body))}
+	if err := attemptDownload(context.Background(), server.Client(), release, dest, sha256.New()); err != nil {
+		t.Fatalf("attemptDownload: %v", err)
+	}
+
+	contents, err := os.ReadFile(dest)
+	if err != nil {
+		t.Fatalf("reading dest: %v", err)
+	}
+	if string(contents) != body {
+		t.Errorf("dest contents = %q, want %q", contents, body)
+	}
+}
+
+func TestDownloadRetriesOnServerError(t *testing.T) {
+	body := "the quick brown fox jumps over the lazy dog"
+	attempts := 0
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts == 1 {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Length", fmt.Sprintf("%d", len(body)))
+		w.Write([]byte(body))
+	}))
+	defer server.Close()
+
+	dest := filepath.Join(t.TempDir(), "archive")
+	release := &releaseBinary{FileName: "test.tar.gz", Link: server.URL, Size: int64(len(body))}
+
+	got, err := download(server.Client(), release, dest)
+	if err != nil {
+		t.Fatalf("download: %v", err)
+	}
+
+	want := sha256.Sum256([]byte(body))
+	if got != hex.EncodeToString(want[:]) {
+		t.Errorf("download hash = %s, want %s", got, hex.EncodeToString(want[:]))
+	}
+	if attempts != 2 {
+		t.Errorf("server saw %d attempts, want 2", attempts)
+	}
+}
+
+func TestAttemptDownloadRejectsSizeMismatch(t *testing.T) {
+	body := "the quick brown fox jumps over the lazy dog"
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Length", fmt.Sprintf("%d", len(body)))
+		w.Write([]byte(body))
+	}))
+	defer server.Close()
+
+	dest := filepath.Join(t.TempDir(), "archive")
+	release := &releaseBinary{FileName: "test.tar.gz", Link: server.URL, Size: int64(len(body) + 1)}
+
+	if err := attemptDownload(context.Background(), server.Client(), release, dest, sha256.New()); err == nil {
+		t.Fatal("attemptDownload: expected error on size mismatch, got nil")
+	}
+}
@@ -0,0 +1,180 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package main
+
+import "fmt"
+
+// releaseBinary describes a single downloadable JDK archive, normalized
+// across vendors so the rest of the service never needs to know which API
+// produced it.
+type releaseBinary struct {
+	FileName       string         `json:"binary_name"`
+	Platform       string         `json:"os"`
+	Arch           string         `json:"architecture"`
+	Link           string         `json:"binary_link"`
+	ReleaseVersion releaseVersion `json:"version_data"`
+
+	// ChecksumLink and SignatureLink point at the vendor's published
+	// `.sha256.txt` and detached `.sig` sidecars for Link, when it
+	// publishes them. Either may be empty, in which case that half of
+	// verifyingDownload's integrity check is skipped.
+	ChecksumLink  string `json:"checksum_link,omitempty"`
+	SignatureLink string `json:"signature_link,omitempty"`
+
+	// Size is the expected archive size in bytes, when the vendor reports
+	// one. download validates the response's Content-Length against it
+	// before accepting the archive. Zero means "unknown" and skips that
+	// check.
+	Size int64 `json:"size,omitempty"`
+
+	// Vendor records which provider resolved this release so that
+	// downloadRelease can route back to the right one, and so a /versions
+	// client can tell entries from different vendors apart and pass the
+	// right one back as ?vendor= to /jlink.
+	Vendor string `json:"vendor,omitempty"`
+}
+
+type releaseVersion struct {
+	Version string `json:"openjdk_version"`
+}
+
+// Provider is a JDK vendor backend capable of resolving and downloading
+// runtime images. Each vendor's API quirks are contained behind this
+// interface so that lookupRelease / lookupLatestRelease / listReleases /
+// downloadRelease can treat every vendor identically and fall through to
+// a secondary vendor when the primary doesn't carry what was asked for.
+type Provider interface {
+	// LookupVersion finds a release matching the given triple and exact
+	// OpenJDK version string. A nil release with a nil error means the
+	// vendor simply doesn't have it.
+	LookupVersion(arch, platform, implementation, version string) (*releaseBinary, error)
+
+	// LookupLatest finds the latest release of the given major version.
+	LookupLatest(arch, platform, implementation string, majorVersion int) (*releaseBinary, error)
+
+	// ListVersions returns every release the vendor publishes for the given
+	// triple. arch, platform, and implementation may be left empty to mean
+	// "don't filter on this".
+	ListVersions(arch, platform, implementation string) ([]releaseBinary, error)
+
+	// Download fetches and extracts the given release, returning the path
+	// to the extracted JDK home.
+	Download(release *releaseBinary) (string, error)
+}
+
+// defaultVendor is used when a request doesn't specify ?vendor=.
+const defaultVendor = "adoptium"
+
+// vendorOrder is the fallback order tried after the requested vendor draws
+// a blank. adoptium is the broadest catch-all so every other vendor falls
+// back to it first.
+var vendorOrder = []string{"adoptium", "zulu", "corretto", "liberica", "graalvm"}
+
+var providers = map[string]Provider{
+	"adoptium": adoptiumProvider{},
+	"zulu":     zuluProvider{},
+	"corretto": correttoProvider{},
+	"liberica": libericaProvider{},
+	"graalvm":  graalvmProvider{},
+}
+
+// resolveProvider returns the Provider for vendor, defaulting to
+// defaultVendor when vendor is empty.
+func resolveProvider(vendor string) (Provider, error) {
+	if vendor == "" {
+		vendor = defaultVendor
+	}
+	p, ok := providers[vendor]
+	if !ok {
+		return nil, fmt.Errorf("unknown vendor %q", vendor)
+	}
+	return p, nil
+}
+
+// fallbackVendors lists the vendors to try, in order, after vendor has been
+// tried and failed to carry a requested triple.
+func fallbackVendors(vendor string) []string {
+	order := make([]string, 0, len(vendorOrder)-1)
+	for _, name := range vendorOrder {
+		if name != vendor {
+			order = append(order, name)
+		}
+	}
+	return order
+}
+
+// lookupRelease finds a release for the given version string, trying vendor
+// first and falling through to the other providers if it comes up empty.
+// Results are served out of releaseCache rather than hitting the vendor API
+// on every call.
+func lookupRelease(vendor, arch, platform, implementation, version string) (*releaseBinary, error) {
+	if vendor == "" {
+		vendor = defaultVendor
+	}
+	majorVersion, _ := getMajorVersion(version)
+
+	if _, err := resolveProvider(vendor); err != nil {
+		return nil, err
+	}
+
+	for _, name := range append([]string{vendor}, fallbackVendors(vendor)...) {
+		key := releaseCacheKey{vendor: name, majorVersion: majorVersion, implementation: implementation, platform: platform, arch: arch}
+		releases, err := cachedReleases(key)
+		if err != nil {
+			continue
+		}
+		for _, release := range releases {
+			if release.ReleaseVersion.Version == version {
+				release.Vendor = name
+				return &release, nil
+			}
+		}
+	}
+
+	return nil, nil
+}
+
+// lookupLatestRelease finds the latest release of the given major version,
+// trying vendor first and falling through to the other providers if it
+// comes up empty. Results are served out of releaseCache rather than
+// hitting the vendor API on every call.
+func lookupLatestRelease(vendor, arch, platform, implementation string, majorVersion int) (*releaseBinary, error) {
+	if vendor == "" {
+		vendor = defaultVendor
+	}
+
+	if _, err := resolveProvider(vendor); err != nil {
+		return nil, err
+	}
+
+	for _, name := range append([]string{vendor}, fallbackVendors(vendor)...) {
+		key := releaseCacheKey{vendor: name, majorVersion: majorVersion, implementation: implementation, platform: platform, arch: arch}
+		release, err := cachedLatest(key)
+		if err != nil || release == nil {
+			continue
+		}
+		release.Vendor = name
+		return release, nil
+	}
+
+	return nil, nil
+}
+
+// downloadRelease downloads a JDK runtime image from whichever vendor
+// resolved it.
+func downloadRelease(release *releaseBinary) (string, error) {
+	provider, err := resolveProvider(release.Vendor)
+	if err != nil {
+		return "", err
+	}
+	return provider.Download(release)
+}
@@ -0,0 +1,143 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// adoptiumProvider resolves releases against Eclipse Adoptium's disco/v3
+// API, the supported successor to the AdoptOpenJDK v2 API this service used
+// to call directly.
+type adoptiumProvider struct{}
+
+type adoptiumAsset struct {
+	Binary struct {
+		OS           string `json:"os"`
+		Architecture string `json:"architecture"`
+		ImageType    string `json:"image_type"`
+		Package      struct {
+			Name          string `json:"name"`
+			Link          string `json:"link"`
+			Size          int64  `json:"size"`
+			ChecksumLink  string `json:"checksum_link"`
+			SignatureLink string `json:"signature_link"`
+		} `json:"package"`
+	} `json:"binary"`
+	Version struct {
+		OpenjdkVersion string `json:"openjdk_version"`
+	} `json:"version"`
+}
+
+func (adoptiumProvider) LookupVersion(arch, platform, implementation, version string) (*releaseBinary, error) {
+	res, err := adoptOpenJdk.Get(fmt.Sprintf(
+		"https://api.adoptium.net/v3/assets/version/%s?architecture=%s&os=%s&image_type=jdk&jvm_impl=%s",
+		version, arch, platform, implementation))
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	var assets []adoptiumAsset
+	if err := json.NewDecoder(res.Body).Decode(&assets); err != nil {
+		return nil, err
+	}
+
+	return firstAdoptiumMatch(assets, arch, platform), nil
+}
+
+func (adoptiumProvider) LookupLatest(arch, platform, implementation string, majorVersion int) (*releaseBinary, error) {
+	res, err := adoptOpenJdk.Get(fmt.Sprintf(
+		"https://api.adoptium.net/v3/assets/latest/%d/%s?architecture=%s&os=%s&image_type=jdk",
+		majorVersion, implementation, arch, platform))
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	var assets []adoptiumAsset
+	if err := json.NewDecoder(res.Body).Decode(&assets); err != nil {
+		return nil, err
+	}
+
+	return firstAdoptiumMatch(assets, arch, platform), nil
+}
+
+func (adoptiumProvider) ListVersions(arch, platform, implementation string) ([]releaseBinary, error) {
+	var releases []releaseBinary
+
+	// Adoptium has no "list everything" endpoint, so walk feature versions
+	// 6 through 21; a version with no builds, or whose request fails, adds
+	// nothing.
+	for _, majorVersion := range []int{6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19, 20, 21} {
+		url := fmt.Sprintf("https://api.adoptium.net/v3/assets/feature_releases/%d/ga?image_type=jdk", majorVersion)
+		if arch != "" {
+			url += "&architecture=" + arch
+		}
+		if platform != "" {
+			url += "&os=" + platform
+		}
+		if implementation != "" {
+			url += "&jvm_impl=" + implementation
+		}
+
+		res, err := adoptOpenJdk.Get(url)
+		if err != nil {
+			continue
+		}
+
+		var assets []adoptiumAsset
+		err = json.NewDecoder(res.Body).Decode(&assets)
+		res.Body.Close()
+		if err != nil {
+			continue
+		}
+
+		for _, asset := range assets {
+			releases = append(releases, adoptiumAssetToRelease(asset))
+		}
+	}
+
+	return releases, nil
+}
+
+func (adoptiumProvider) Download(release *releaseBinary) (string, error) {
+	return downloadAndExtract(github, release)
+}
+
+// firstAdoptiumMatch returns the first asset matching arch/platform,
+// converted to a releaseBinary, or nil if none matched.
+func firstAdoptiumMatch(assets []adoptiumAsset, arch, platform string) *releaseBinary {
+	for _, asset := range assets {
+		if asset.Binary.Architecture == arch && asset.Binary.OS == platform {
+			release := adoptiumAssetToRelease(asset)
+			return &release
+		}
+	}
+	return nil
+}
+
+func adoptiumAssetToRelease(asset adoptiumAsset) releaseBinary {
+	return releaseBinary{
+		FileName:      asset.Binary.Package.Name,
+		Platform:      asset.Binary.OS,
+		Arch:          asset.Binary.Architecture,
+		Link:          asset.Binary.Package.Link,
+		Size:          asset.Binary.Package.Size,
+		ChecksumLink:  asset.Binary.Package.ChecksumLink,
+		SignatureLink: asset.Binary.Package.SignatureLink,
+		ReleaseVersion: releaseVersion{
+			Version: asset.Version.OpenjdkVersion,
+		},
+	}
+}
@@ -0,0 +1,161 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// corretto is the HTTP client used to talk to Amazon's Corretto download
+// index.
+var corretto = &http.Client{}
+
+const correttoIndexURL = "https://corretto.github.io/corretto-downloads/latest_links/indexmap_with_checksum.json"
+
+// correttoProvider resolves releases against Amazon Corretto's published
+// index of latest links. The index only carries the latest release of each
+// major version, so LookupVersion finds a version only when it is exactly
+// that latest release.
+type correttoProvider struct{}
+
+// correttoIndex mirrors indexmap_with_checksum.json: majorVersion ->
+// imageType ("jdk"/"jre") -> target (e.g. "linux-x64") -> artifact.
+type correttoIndex struct {
+	Versions map[string]map[string]map[string]correttoArtifact `json:"latest_links"`
+}
+
+type correttoArtifact struct {
+	Resource string `json:"resource"`
+	Checksum string `json:"checksum"`
+}
+
+func (correttoProvider) LookupVersion(arch, platform, implementation, version string) (*releaseBinary, error) {
+	majorVersion, _ := getMajorVersion(version)
+	release, err := correttoProvider{}.LookupLatest(arch, platform, implementation, majorVersion)
+	if err != nil || release == nil {
+		return nil, err
+	}
+	if release.ReleaseVersion.Version != version {
+		return nil, nil
+	}
+	return release, nil
+}
+
+func (correttoProvider) LookupLatest(arch, platform, implementation string, majorVersion int) (*releaseBinary, error) {
+	index, err := correttoFetchIndex()
+	if err != nil {
+		return nil, err
+	}
+
+	byTarget, ok := index.Versions[fmt.Sprintf("%d", majorVersion)]["jdk"]
+	if !ok {
+		return nil, nil
+	}
+	artifact, ok := byTarget[correttoTarget(platform, arch)]
+	if !ok {
+		return nil, nil
+	}
+
+	return &releaseBinary{
+		FileName:       fmt.Sprintf("amazon-corretto-%d-%s-%s-jdk.tar.gz", majorVersion, arch, platform),
+		Platform:       platform,
+		Arch:           arch,
+		Link:           artifact.Resource,
+		ChecksumLink:   artifact.Resource + ".sha256",
+		ReleaseVersion: releaseVersion{Version: correttoVersionFromResource(artifact.Resource)},
+	}, nil
+}
+
+func (correttoProvider) ListVersions(arch, platform, implementation string) ([]releaseBinary, error) {
+	index, err := correttoFetchIndex()
+	if err != nil {
+		return nil, err
+	}
+
+	var releases []releaseBinary
+	for majorVersion, byImageType := range index.Versions {
+		byTarget, ok := byImageType["jdk"]
+		if !ok {
+			continue
+		}
+		for target, artifact := range byTarget {
+			if arch != "" && !strings.Contains(target, arch) {
+				continue
+			}
+			if platform != "" && !strings.Contains(target, platform) {
+				continue
+			}
+			targetPlatform, targetArch := correttoSplitTarget(target)
+			releases = append(releases, releaseBinary{
+				FileName:       fmt.Sprintf("amazon-corretto-%s-%s-jdk.tar.gz", majorVersion, target),
+				Platform:       targetPlatform,
+				Arch:           targetArch,
+				Link:           artifact.Resource,
+				ChecksumLink:   artifact.Resource + ".sha256",
+				ReleaseVersion: releaseVersion{Version: correttoVersionFromResource(artifact.Resource)},
+			})
+		}
+	}
+	return releases, nil
+}
+
+func (correttoProvider) Download(release *releaseBinary) (string, error) {
+	return downloadAndExtract(corretto, release)
+}
+
+func correttoFetchIndex() (*correttoIndex, error) {
+	res, err := corretto.Get(correttoIndexURL)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	var index correttoIndex
+	if err := json.NewDecoder(res.Body).Decode(&index); err != nil {
+		return nil, err
+	}
+	return &index, nil
+}
+
+// correttoTarget maps this service's platform/arch vocabulary onto the
+// $os-$arch keys Corretto's index uses.
+func correttoTarget(platform, arch string) string {
+	return fmt.Sprintf("%s-%s", platform, arch)
+}
+
+// correttoSplitTarget reverses correttoTarget, splitting an index target key
+// like "alpine-linux-x64" back into platform ("alpine-linux") and arch
+// ("x64"). It splits on the last hyphen since arch segments never contain
+// one, while some of Corretto's platform segments do.
+func correttoSplitTarget(target string) (platform, arch string) {
+	i := strings.LastIndex(target, "-")
+	if i < 0 {
+		return target, ""
+	}
+	return target[:i], target[i+1:]
+}
+
+// correttoVersionFromResource pulls the full OpenJDK version out of a
+// Corretto download URL, which embeds it as the path segment right after
+// "resources/", e.g. ".../resources/17.0.9.9.1/amazon-corretto-...tar.gz".
+func correttoVersionFromResource(url string) string {
+	parts := strings.Split(url, "/")
+	for i, part := range parts {
+		if part == "resources" && i+1 < len(parts) {
+			return parts[i+1]
+		}
+	}
+	return ""
+}
@@ -0,0 +1,65 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package main
+
+import "testing"
+
+func TestCorrettoVersionFromResource(t *testing.T) {
+	cases := []struct {
+		url  string
+		want string
+	}{
+		{
+			url:  "https://corretto.aws/downloads/resources/17.0.9.9.1/amazon-corretto-17.0.9.9.1-linux-x64.tar.gz",
+			want: "17.0.9.9.1",
+		},
+		{
+			url:  "https://corretto.aws/downloads/resources/8.392.08.1/amazon-corretto-8.392.08.1-linux-x64.tar.gz",
+			want: "8.392.08.1",
+		},
+		{
+			url:  "https://corretto.aws/downloads/latest/amazon-corretto-17-x64-linux-jdk.tar.gz",
+			want: "",
+		},
+	}
+
+	for _, c := range cases {
+		if got := correttoVersionFromResource(c.url); got != c.want {
+			t.Errorf("correttoVersionFromResource(%q) = %q, want %q", c.url, got, c.want)
+		}
+	}
+}
+
+func TestCorrettoTarget(t *testing.T) {
+	if got, want := correttoTarget("linux", "x64"), "linux-x64"; got != want {
+		t.Errorf("correttoTarget(linux, x64) = %q, want %q", got, want)
+	}
+}
+
+func TestCorrettoSplitTarget(t *testing.T) {
+	cases := []struct {
+		target       string
+		wantPlatform string
+		wantArch     string
+	}{
+		{"linux-x64", "linux", "x64"},
+		{"alpine-linux-x64", "alpine-linux", "x64"},
+		{"macosx-aarch64", "macosx", "aarch64"},
+	}
+
+	for _, c := range cases {
+		platform, arch := correttoSplitTarget(c.target)
+		if platform != c.wantPlatform || arch != c.wantArch {
+			t.Errorf("correttoSplitTarget(%q) = (%q, %q), want (%q, %q)", c.target, platform, arch, c.wantPlatform, c.wantArch)
+		}
+	}
+}
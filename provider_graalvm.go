@@ -0,0 +1,130 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// graalvmProvider resolves releases against the GraalVM Community Edition
+// GitHub releases, since GraalVM publishes images there rather than
+// through a dedicated metadata API. It's the only way to get a GraalVM
+// image out of this service.
+type graalvmProvider struct{}
+
+type graalvmGithubRelease struct {
+	TagName string `json:"tag_name"`
+	Assets  []struct {
+		Name               string `json:"name"`
+		BrowserDownloadURL string `json:"browser_download_url"`
+	} `json:"assets"`
+}
+
+func (graalvmProvider) LookupVersion(arch, platform, implementation, version string) (*releaseBinary, error) {
+	releases, err := graalvmListReleases()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, rel := range releases {
+		if strings.TrimPrefix(rel.TagName, "jdk-") != version {
+			continue
+		}
+		if asset := graalvmFindAsset(rel, arch, platform); asset != nil {
+			return asset, nil
+		}
+	}
+	return nil, nil
+}
+
+func (graalvmProvider) LookupLatest(arch, platform, implementation string, majorVersion int) (*releaseBinary, error) {
+	releases, err := graalvmListReleases()
+	if err != nil {
+		return nil, err
+	}
+
+	prefix := fmt.Sprintf("jdk-%d", majorVersion)
+	for _, rel := range releases {
+		if !strings.HasPrefix(strings.TrimPrefix(rel.TagName, "vm-"), prefix) && !strings.Contains(rel.TagName, prefix) {
+			continue
+		}
+		if asset := graalvmFindAsset(rel, arch, platform); asset != nil {
+			return asset, nil
+		}
+	}
+	return nil, nil
+}
+
+func (graalvmProvider) ListVersions(arch, platform, implementation string) ([]releaseBinary, error) {
+	releases, err := graalvmListReleases()
+	if err != nil {
+		return nil, err
+	}
+
+	var out []releaseBinary
+	for _, rel := range releases {
+		for _, asset := range rel.Assets {
+			if !strings.HasSuffix(asset.Name, ".tar.gz") {
+				continue
+			}
+			if arch != "" && !strings.Contains(asset.Name, arch) {
+				continue
+			}
+			if platform != "" && !strings.Contains(asset.Name, platform) {
+				continue
+			}
+			out = append(out, releaseBinary{
+				FileName:       asset.Name,
+				Platform:       platform,
+				Arch:           arch,
+				Link:           asset.BrowserDownloadURL,
+				ReleaseVersion: releaseVersion{Version: rel.TagName},
+			})
+		}
+	}
+	return out, nil
+}
+
+func (graalvmProvider) Download(release *releaseBinary) (string, error) {
+	return downloadAndExtract(github, release)
+}
+
+func graalvmListReleases() ([]graalvmGithubRelease, error) {
+	res, err := github.Get("https://api.github.com/repos/graalvm/graalvm-ce-builds/releases")
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	var releases []graalvmGithubRelease
+	if err := json.NewDecoder(res.Body).Decode(&releases); err != nil {
+		return nil, err
+	}
+	return releases, nil
+}
+
+func graalvmFindAsset(rel graalvmGithubRelease, arch, platform string) *releaseBinary {
+	for _, asset := range rel.Assets {
+		if strings.Contains(asset.Name, platform) && strings.Contains(asset.Name, arch) && strings.HasSuffix(asset.Name, ".tar.gz") {
+			return &releaseBinary{
+				FileName:       asset.Name,
+				Platform:       platform,
+				Arch:           arch,
+				Link:           asset.BrowserDownloadURL,
+				ReleaseVersion: releaseVersion{Version: rel.TagName},
+			}
+		}
+	}
+	return nil
+}
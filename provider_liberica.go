@@ -0,0 +1,105 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// bellsoft is the HTTP client used to talk to BellSoft's Liberica API.
+var bellsoft = &http.Client{}
+
+// libericaProvider resolves releases against BellSoft's Liberica API
+// (api.bell-sw.com), which covers architectures like riscv64 that the
+// other vendors don't build for.
+type libericaProvider struct{}
+
+type libericaRelease struct {
+	FilenameField string `json:"filename"`
+	DownloadURL   string `json:"downloadUrl"`
+	OS            string `json:"os"`
+	Architecture  string `json:"architecture"`
+	Version       string `json:"version"`
+}
+
+func (libericaProvider) LookupVersion(arch, platform, implementation, version string) (*releaseBinary, error) {
+	releases, err := libericaQuery(fmt.Sprintf(
+		"https://api.bell-sw.com/v1/liberica/releases?version=%s&os=%s&arch=%s&package-type=jdk&bundle-type=jdk",
+		version, platform, arch))
+	if err != nil || len(releases) == 0 {
+		return nil, err
+	}
+	release := libericaReleaseToRelease(releases[0])
+	return &release, nil
+}
+
+func (libericaProvider) LookupLatest(arch, platform, implementation string, majorVersion int) (*releaseBinary, error) {
+	releases, err := libericaQuery(fmt.Sprintf(
+		"https://api.bell-sw.com/v1/liberica/releases?version-feature=%d&os=%s&arch=%s&package-type=jdk&bundle-type=jdk&latest=true",
+		majorVersion, platform, arch))
+	if err != nil || len(releases) == 0 {
+		return nil, err
+	}
+	release := libericaReleaseToRelease(releases[0])
+	return &release, nil
+}
+
+func (libericaProvider) ListVersions(arch, platform, implementation string) ([]releaseBinary, error) {
+	url := "https://api.bell-sw.com/v1/liberica/releases?package-type=jdk&bundle-type=jdk"
+	if platform != "" {
+		url += "&os=" + platform
+	}
+	if arch != "" {
+		url += "&arch=" + arch
+	}
+
+	releases, err := libericaQuery(url)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]releaseBinary, 0, len(releases))
+	for _, r := range releases {
+		out = append(out, libericaReleaseToRelease(r))
+	}
+	return out, nil
+}
+
+func (libericaProvider) Download(release *releaseBinary) (string, error) {
+	return downloadAndExtract(bellsoft, release)
+}
+
+func libericaQuery(url string) ([]libericaRelease, error) {
+	res, err := bellsoft.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	var releases []libericaRelease
+	if err := json.NewDecoder(res.Body).Decode(&releases); err != nil {
+		return nil, err
+	}
+	return releases, nil
+}
+
+func libericaReleaseToRelease(r libericaRelease) releaseBinary {
+	return releaseBinary{
+		FileName:       r.FilenameField,
+		Platform:       r.OS,
+		Arch:           r.Architecture,
+		Link:           r.DownloadURL,
+		ReleaseVersion: releaseVersion{Version: r.Version},
+	}
+}
@@ -0,0 +1,147 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// azul is the HTTP client used to talk to Azul's metadata API.
+var azul = &http.Client{}
+
+// zuluProvider resolves releases against Azul's Zulu metadata API
+// (api.azul.com/metadata/v1), which additionally covers architectures and
+// old major versions the Adoptium project never built.
+type zuluProvider struct{}
+
+type zuluPackage struct {
+	Name        string `json:"name"`
+	DownloadURL string `json:"download_url"`
+	JavaVersion []int  `json:"java_version"`
+	OS          string `json:"os"`
+	Arch        string `json:"arch"`
+	JavaPackage string `json:"java_package_type"`
+}
+
+func (zuluProvider) LookupVersion(arch, platform, implementation, version string) (*releaseBinary, error) {
+	packages, err := zuluQuery(fmt.Sprintf(
+		"https://api.azul.com/metadata/v1/zulu/packages/?os=%s&arch=%s&java_package_type=jdk&archive_type=tar.gz",
+		zuluOS(platform), zuluArch(arch)))
+	if err != nil {
+		return nil, err
+	}
+
+	for _, pkg := range packages {
+		if zuluVersionString(pkg.JavaVersion) == version {
+			release := zuluPackageToRelease(pkg)
+			return &release, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func (zuluProvider) LookupLatest(arch, platform, implementation string, majorVersion int) (*releaseBinary, error) {
+	packages, err := zuluQuery(fmt.Sprintf(
+		"https://api.azul.com/metadata/v1/zulu/packages/?java_version=%d&os=%s&arch=%s&java_package_type=jdk&archive_type=tar.gz&latest=true",
+		majorVersion, zuluOS(platform), zuluArch(arch)))
+	if err != nil {
+		return nil, err
+	}
+	if len(packages) == 0 {
+		return nil, nil
+	}
+
+	release := zuluPackageToRelease(packages[0])
+	return &release, nil
+}
+
+func (zuluProvider) ListVersions(arch, platform, implementation string) ([]releaseBinary, error) {
+	url := "https://api.azul.com/metadata/v1/zulu/packages/?java_package_type=jdk&archive_type=tar.gz"
+	if platform != "" {
+		url += "&os=" + zuluOS(platform)
+	}
+	if arch != "" {
+		url += "&arch=" + zuluArch(arch)
+	}
+
+	packages, err := zuluQuery(url)
+	if err != nil {
+		return nil, err
+	}
+
+	releases := make([]releaseBinary, 0, len(packages))
+	for _, pkg := range packages {
+		releases = append(releases, zuluPackageToRelease(pkg))
+	}
+	return releases, nil
+}
+
+func (zuluProvider) Download(release *releaseBinary) (string, error) {
+	return downloadAndExtract(azul, release)
+}
+
+func zuluQuery(url string) ([]zuluPackage, error) {
+	res, err := azul.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	var packages []zuluPackage
+	if err := json.NewDecoder(res.Body).Decode(&packages); err != nil {
+		return nil, err
+	}
+	return packages, nil
+}
+
+func zuluPackageToRelease(pkg zuluPackage) releaseBinary {
+	return releaseBinary{
+		FileName:     pkg.Name,
+		Platform:     pkg.OS,
+		Arch:         pkg.Arch,
+		Link:         pkg.DownloadURL,
+		ChecksumLink: pkg.DownloadURL + ".sha256.txt",
+		ReleaseVersion: releaseVersion{
+			Version: zuluVersionString(pkg.JavaVersion),
+		},
+	}
+}
+
+func zuluVersionString(parts []int) string {
+	version := ""
+	for i, part := range parts {
+		if i > 0 {
+			version += "."
+		}
+		version += fmt.Sprint(part)
+	}
+	return version
+}
+
+// zuluOS and zuluArch translate this service's platform/arch vocabulary
+// into Azul's, which mostly but not always agrees with AdoptOpenJDK's.
+func zuluOS(platform string) string {
+	if platform == "mac" {
+		return "macos"
+	}
+	return platform
+}
+
+func zuluArch(arch string) string {
+	if arch == "x64" {
+		return "x86_64"
+	}
+	return arch
+}
@@ -0,0 +1,173 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package main
+
+import (
+	"flag"
+	"log"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// releaseCacheTTL controls how long a resolved release is served from
+// memory before the background refresher re-fetches it from the vendor.
+var releaseCacheTTL = flag.Duration("release-cache-ttl", 8*time.Hour, "how often to refresh cached release lookups")
+
+type releaseCacheKey struct {
+	vendor         string
+	majorVersion   int
+	implementation string
+	platform       string
+	arch           string
+}
+
+type releaseCacheEntry struct {
+	releases  []releaseBinary
+	latest    *releaseBinary
+	fetchedAt time.Time
+}
+
+var releaseCache = struct {
+	sync.Mutex
+	entries map[releaseCacheKey]*releaseCacheEntry
+}{entries: make(map[releaseCacheKey]*releaseCacheEntry)}
+
+// StartReleaseCacheRefresher launches the goroutine that keeps every known
+// releaseCache entry warm, refreshing each one roughly every
+// *releaseCacheTTL. It's meant to be started once from main.
+func StartReleaseCacheRefresher() {
+	go func() {
+		for range time.Tick(*releaseCacheTTL) {
+			refreshReleaseCache()
+		}
+	}()
+}
+
+// refreshReleaseCache re-populates every entry currently in the cache. A
+// vendor that errors keeps serving its last-known-good (stale) entry rather
+// than being wiped, so a transient upstream outage never makes this service
+// look down too.
+func refreshReleaseCache() {
+	releaseCache.Lock()
+	keys := make([]releaseCacheKey, 0, len(releaseCache.entries))
+	for key := range releaseCache.entries {
+		keys = append(keys, key)
+	}
+	releaseCache.Unlock()
+
+	for _, key := range keys {
+		entry, err := fetchReleaseCacheEntry(key)
+		if err != nil {
+			log.Printf("release cache: refresh of %+v failed, serving stale entry: %v", key, err)
+			continue
+		}
+		releaseCache.Lock()
+		releaseCache.entries[key] = entry
+		releaseCache.Unlock()
+	}
+}
+
+// cachedReleases returns every release the vendor has published for key's
+// major version, serving from cache when fresh and otherwise blocking to
+// populate it.
+func cachedReleases(key releaseCacheKey) ([]releaseBinary, error) {
+	entry, err := getOrPopulate(key)
+	if err != nil {
+		return nil, err
+	}
+	return entry.releases, nil
+}
+
+// cachedLatest returns the latest release for key's major version, serving
+// from cache when fresh and otherwise blocking to populate it.
+func cachedLatest(key releaseCacheKey) (*releaseBinary, error) {
+	entry, err := getOrPopulate(key)
+	if err != nil {
+		return nil, err
+	}
+	return entry.latest, nil
+}
+
+func getOrPopulate(key releaseCacheKey) (*releaseCacheEntry, error) {
+	releaseCache.Lock()
+	entry, ok := releaseCache.entries[key]
+	releaseCache.Unlock()
+
+	if ok && time.Since(entry.fetchedAt) < *releaseCacheTTL {
+		return entry, nil
+	}
+
+	fresh, err := fetchReleaseCacheEntry(key)
+	if err != nil {
+		if ok {
+			// Upstream is down but we have something stale to serve.
+			log.Printf("release cache: populate of %+v failed, serving stale entry: %v", key, err)
+			return entry, nil
+		}
+		return nil, err
+	}
+
+	releaseCache.Lock()
+	releaseCache.entries[key] = fresh
+	releaseCache.Unlock()
+
+	return fresh, nil
+}
+
+func fetchReleaseCacheEntry(key releaseCacheKey) (*releaseCacheEntry, error) {
+	provider, err := resolveProvider(key.vendor)
+	if err != nil {
+		return nil, err
+	}
+
+	vendor := key.vendor
+	if vendor == "" {
+		vendor = defaultVendor
+	}
+
+	// listReleases already caches the full cross-vendor matrix for
+	// (arch, platform, implementation), so every majorVersion we're asked to
+	// cache here filters out of the same fetch instead of triggering its own
+	// walk of the vendor's API.
+	all, err := listReleases(key.arch, key.platform, key.implementation)
+	if err != nil {
+		return nil, err
+	}
+
+	releases := make([]releaseBinary, 0)
+	for _, release := range all {
+		if release.Vendor != vendor {
+			continue
+		}
+		if major, _ := getMajorVersion(release.ReleaseVersion.Version); major == key.majorVersion {
+			releases = append(releases, release)
+		}
+	}
+
+	latest, err := provider.LookupLatest(key.arch, key.platform, key.implementation, key.majorVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	return &releaseCacheEntry{releases: releases, latest: latest, fetchedAt: time.Now()}, nil
+}
+
+// handleAdminRefresh forces an immediate re-population of every cached
+// release lookup, for operators who don't want to wait out the TTL after a
+// vendor fixes an outage.
+func handleAdminRefresh(c *gin.Context) {
+	refreshReleaseCache()
+	c.JSON(http.StatusOK, gin.H{"status": "refreshed"})
+}
@@ -0,0 +1,107 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package main
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeCacheProvider is a Provider whose LookupLatest/ListVersions can be
+// toggled to fail, so tests can simulate a vendor outage without touching
+// the network.
+type fakeCacheProvider struct {
+	mu       sync.Mutex
+	fail     bool
+	latest   releaseBinary
+	releases []releaseBinary
+}
+
+func (p *fakeCacheProvider) LookupVersion(arch, platform, implementation, version string) (*releaseBinary, error) {
+	return nil, nil
+}
+
+func (p *fakeCacheProvider) LookupLatest(arch, platform, implementation string, majorVersion int) (*releaseBinary, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.fail {
+		return nil, errors.New("fakeCacheProvider: simulated outage")
+	}
+	latest := p.latest
+	return &latest, nil
+}
+
+func (p *fakeCacheProvider) ListVersions(arch, platform, implementation string) ([]releaseBinary, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.fail {
+		return nil, errors.New("fakeCacheProvider: simulated outage")
+	}
+	return p.releases, nil
+}
+
+func (p *fakeCacheProvider) Download(release *releaseBinary) (string, error) {
+	return "", nil
+}
+
+// TestGetOrPopulateServesStaleOnRefreshFailure proves the core claim of the
+// release cache: once an entry has been populated, a refresh that fails
+// against the vendor still serves the last-known-good entry instead of
+// propagating the error.
+func TestGetOrPopulateServesStaleOnRefreshFailure(t *testing.T) {
+	const vendor = "fake-stale-vendor"
+	fake := &fakeCacheProvider{
+		latest:   releaseBinary{ReleaseVersion: releaseVersion{Version: "17.0.1"}},
+		releases: []releaseBinary{{ReleaseVersion: releaseVersion{Version: "17.0.1"}}},
+	}
+
+	providers[vendor] = fake
+	vendorOrder = append(vendorOrder, vendor)
+	t.Cleanup(func() {
+		delete(providers, vendor)
+		vendorOrder = vendorOrder[:len(vendorOrder)-1]
+
+		releaseCache.Lock()
+		delete(releaseCache.entries, releaseCacheKey{vendor: vendor, majorVersion: 17, arch: "x64", platform: "linux"})
+		releaseCache.Unlock()
+	})
+
+	key := releaseCacheKey{vendor: vendor, majorVersion: 17, arch: "x64", platform: "linux"}
+
+	fresh, err := getOrPopulate(key)
+	if err != nil {
+		t.Fatalf("initial getOrPopulate: %v", err)
+	}
+	if fresh.latest == nil || fresh.latest.ReleaseVersion.Version != "17.0.1" {
+		t.Fatalf("initial entry = %+v, want latest version 17.0.1", fresh)
+	}
+
+	// Force the entry stale so the next call attempts a refresh, then make
+	// the vendor start failing.
+	releaseCache.Lock()
+	releaseCache.entries[key].fetchedAt = time.Now().Add(-2 * *releaseCacheTTL)
+	releaseCache.Unlock()
+
+	fake.mu.Lock()
+	fake.fail = true
+	fake.mu.Unlock()
+
+	stale, err := getOrPopulate(key)
+	if err != nil {
+		t.Fatalf("getOrPopulate during outage: unexpected error: %v", err)
+	}
+	if stale.latest == nil || stale.latest.ReleaseVersion.Version != "17.0.1" {
+		t.Errorf("getOrPopulate during outage = %+v, want stale entry with latest version 17.0.1", stale)
+	}
+}
@@ -0,0 +1,167 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package main
+
+import (
+	"bytes"
+	"embed"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"log"
+	"net/http"
+	"os"
+	"strings"
+
+	"golang.org/x/crypto/openpgp"
+)
+
+// vendorKeys embeds whatever vendor public keys have been dropped into
+// keys/ as <vendor>.asc. A vendor with no file here simply has its
+// signature check skipped (see vendorKeyring) until one is added — it
+// never blocks on a key that was never committed.
+//
+//go:embed keys
+var vendorKeys embed.FS
+
+// errNoVendorKey means vendor has no pinned key embedded yet.
+var errNoVendorKey = errors.New("no pinned key embedded for vendor")
+
+// noVerify disables checksum and signature verification of downloaded
+// archives. It exists for testing against vendors that don't publish
+// sidecars (or local mirrors); production should always leave this false.
+var noVerify = flag.Bool("no-verify", false, "skip checksum/signature verification of downloaded archives")
+
+// verifyingDownload downloads release.Link to dest and, unless -no-verify
+// is set, confirms the digest download already computed while streaming to
+// disk against release's checksum sidecar, then checks its signature
+// sidecar. Either sidecar may be absent, in which case that half of the
+// check is skipped. If either check fails, the file is deleted.
+func verifyingDownload(client *http.Client, release *releaseBinary, dest string) error {
+	got, err := download(client, release, dest)
+	if err != nil {
+		return err
+	}
+
+	if *noVerify {
+		return nil
+	}
+
+	if release.ChecksumLink != "" {
+		if err := verifyChecksum(client, release, got); err != nil {
+			os.Remove(dest)
+			return err
+		}
+	}
+
+	if release.SignatureLink != "" {
+		if err := verifySignature(client, release, dest); err != nil {
+			os.Remove(dest)
+			return err
+		}
+	}
+
+	return nil
+}
+
+// verifyChecksum fetches release.ChecksumLink and confirms it matches got,
+// the digest already computed while the archive streamed to disk.
+func verifyChecksum(client *http.Client, release *releaseBinary, got string) error {
+	want, err := fetchChecksum(client, release.ChecksumLink)
+	if err != nil {
+		return err
+	}
+
+	if got != want {
+		return fmt.Errorf("checksum mismatch for %s: got %s, want %s", release.FileName, got, want)
+	}
+	return nil
+}
+
+// fetchChecksum fetches and parses a vendor `.sha256.txt` sidecar, which is
+// conventionally "<hex digest>  <filename>".
+func fetchChecksum(client *http.Client, url string) (string, error) {
+	res, err := client.Get(url)
+	if err != nil {
+		return "", err
+	}
+	defer res.Body.Close()
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return "", err
+	}
+
+	fields := strings.Fields(string(body))
+	if len(fields) == 0 {
+		return "", fmt.Errorf("empty checksum response from %s", url)
+	}
+	return strings.ToLower(fields[0]), nil
+}
+
+// verifySignature fetches release.SignatureLink and verifies it as a
+// detached OpenPGP signature over dest, made by one of the pinned vendor
+// keys embedded in the binary. If no key has been pinned for the vendor
+// yet, the check is skipped rather than treated as a failure.
+func verifySignature(client *http.Client, release *releaseBinary, dest string) error {
+	keyring, err := vendorKeyring(release.Vendor)
+	if errors.Is(err, errNoVendorKey) {
+		log.Printf("verify: no pinned key for vendor %q yet, skipping signature check for %s", release.Vendor, release.FileName)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	res, err := client.Get(release.SignatureLink)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	sig, err := io.ReadAll(res.Body)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Open(dest)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	_, err = openpgp.CheckDetachedSignature(keyring, f, bytes.NewReader(sig))
+	if err != nil {
+		return fmt.Errorf("signature verification failed for %s: %w", release.FileName, err)
+	}
+	return nil
+}
+
+// vendorKeyring loads the pinned public key bundled for vendor from
+// keys/<vendor>.asc, returning errNoVendorKey if none has been embedded yet.
+func vendorKeyring(vendor string) (openpgp.EntityList, error) {
+	if vendor == "" {
+		vendor = defaultVendor
+	}
+
+	raw, err := vendorKeys.ReadFile("keys/" + vendor + ".asc")
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, errNoVendorKey
+	}
+	if err != nil {
+		return nil, fmt.Errorf("no pinned key for vendor %q: %w", vendor, err)
+	}
+
+	return openpgp.ReadArmoredKeyRing(bytes.NewReader(raw))
+}
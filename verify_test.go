@@ -0,0 +1,65 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package main
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestFetchChecksum(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("ABCDEF0123  openjdk-17_linux-x64_bin.tar.gz\n"))
+	}))
+	defer server.Close()
+
+	got, err := fetchChecksum(server.Client(), server.URL)
+	if err != nil {
+		t.Fatalf("fetchChecksum: %v", err)
+	}
+	if want := "abcdef0123"; got != want {
+		t.Errorf("fetchChecksum = %q, want %q", got, want)
+	}
+}
+
+func TestVerifyChecksumMatch(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("deadbeef  file.tar.gz\n"))
+	}))
+	defer server.Close()
+
+	release := &releaseBinary{FileName: "file.tar.gz", ChecksumLink: server.URL}
+	if err := verifyChecksum(server.Client(), release, "deadbeef"); err != nil {
+		t.Errorf("verifyChecksum: unexpected error: %v", err)
+	}
+}
+
+func TestVerifyChecksumMismatch(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("deadbeef  file.tar.gz\n"))
+	}))
+	defer server.Close()
+
+	release := &releaseBinary{FileName: "file.tar.gz", ChecksumLink: server.URL}
+	if err := verifyChecksum(server.Client(), release, "wrongdigest"); err == nil {
+		t.Error("verifyChecksum: expected error on mismatch, got nil")
+	}
+}
+
+func TestVendorKeyringMissingKeySkipsVerification(t *testing.T) {
+	_, err := vendorKeyring("a-vendor-with-no-pinned-key")
+	if !errors.Is(err, errNoVendorKey) {
+		t.Errorf("vendorKeyring error = %v, want errNoVendorKey", err)
+	}
+}
@@ -0,0 +1,112 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package main
+
+import (
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// listVersionsCacheTTL is how long a listReleases result is served from
+// memory before it's refetched from the vendors.
+const listVersionsCacheTTL = 15 * time.Minute
+
+var listVersionsCache struct {
+	sync.Mutex
+	fetchedAt time.Time
+	key       string
+	releases  []releaseBinary
+}
+
+// listReleases returns the full matrix of releases every vendor currently
+// publishes for the given triple, so clients can pick a runtime before
+// calling /jlink instead of each reimplementing the vendor API dance
+// themselves. arch, platform, and implementation may be empty to mean
+// "don't filter on this".
+func listReleases(arch, platform, implementation string) ([]releaseBinary, error) {
+	key := arch + "|" + platform + "|" + implementation
+
+	listVersionsCache.Lock()
+	if listVersionsCache.key == key && time.Since(listVersionsCache.fetchedAt) < listVersionsCacheTTL {
+		releases := listVersionsCache.releases
+		listVersionsCache.Unlock()
+		return releases, nil
+	}
+	listVersionsCache.Unlock()
+
+	var all []releaseBinary
+	for _, vendor := range vendorOrder {
+		releases, err := providers[vendor].ListVersions(arch, platform, implementation)
+		if err != nil {
+			// One vendor being unreachable shouldn't keep clients from
+			// seeing what every other vendor has.
+			continue
+		}
+		for i := range releases {
+			releases[i].Vendor = vendor
+		}
+		all = append(all, releases...)
+	}
+
+	listVersionsCache.Lock()
+	listVersionsCache.key = key
+	listVersionsCache.fetchedAt = time.Now()
+	listVersionsCache.releases = all
+	listVersionsCache.Unlock()
+
+	return all, nil
+}
+
+// RegisterVersionRoutes wires up GET /versions, GET /versions/:majorVersion,
+// and /admin/refresh, and starts the background release cache refresher.
+func RegisterVersionRoutes(r gin.IRouter) {
+	r.GET("/versions", handleListVersions)
+	r.GET("/versions/:majorVersion", handleListVersionsForMajor)
+	r.GET("/admin/refresh", handleAdminRefresh)
+
+	StartReleaseCacheRefresher()
+}
+
+func handleListVersions(c *gin.Context) {
+	releases, err := listReleases(c.Query("arch"), c.Query("os"), c.Query("impl"))
+	if err != nil {
+		c.JSON(http.StatusBadGateway, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, releases)
+}
+
+func handleListVersionsForMajor(c *gin.Context) {
+	majorVersion, err := strconv.Atoi(c.Param("majorVersion"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "majorVersion must be an integer"})
+		return
+	}
+
+	releases, err := listReleases(c.Query("arch"), c.Query("os"), c.Query("impl"))
+	if err != nil {
+		c.JSON(http.StatusBadGateway, gin.H{"error": err.Error()})
+		return
+	}
+
+	filtered := make([]releaseBinary, 0, len(releases))
+	for _, release := range releases {
+		if major, _ := getMajorVersion(release.ReleaseVersion.Version); major == majorVersion {
+			filtered = append(filtered, release)
+		}
+	}
+	c.JSON(http.StatusOK, filtered)
+}